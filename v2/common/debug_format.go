@@ -0,0 +1,105 @@
+package exifcommon
+
+import (
+    "encoding/binary"
+    "hash/crc32"
+
+    "github.com/dsoprea/go-logging"
+)
+
+var (
+    debugFormatEnabled = false
+)
+
+// SetDebugFormat turns the debug-format verification path in FormatEx() on
+// or off globally. It's meant to be flipped on in tests or while chasing
+// down a maker-note desync, not left on in production, since it costs an
+// extra checksum pass over every value decoded.
+func SetDebugFormat(enabled bool) {
+    debugFormatEnabled = enabled
+}
+
+// FormatOptions controls the debug-format sidecar that FormatEx() produces.
+type FormatOptions struct {
+    // DebugFormat forces sidecar generation for this call regardless of the
+    // global SetDebugFormat() setting.
+    DebugFormat bool
+
+    // Offset is the byte offset of this value within its enclosing IFD
+    // entry, recorded into the sidecar purely for the caller's own
+    // bookkeeping (it isn't interpreted by FormatEx()/VerifyDecoded()).
+    Offset int
+}
+
+// FormatSidecar is the synthetic record produced alongside a formatted value
+// when debug-format is enabled, letting a later call to VerifyDecoded()
+// detect that the raw bytes backing a previously-decoded value have drifted
+// out of sync (e.g. because a maker-note parser miscalculated an offset).
+type FormatSidecar struct {
+    Offset    int
+    TagType   TagTypePrimitive
+    UnitCount uint32
+    Checksum  uint32
+}
+
+// FormatEx is Format() plus an opt-in debug-format sidecar. The sidecar is
+// only populated if opts.DebugFormat is true or SetDebugFormat(true) has
+// been called; otherwise sidecar is nil, and the cost is the same as a
+// plain Format() call.
+func FormatEx(rawBytes []byte, tagType TagTypePrimitive, justFirst bool, byteOrder binary.ByteOrder, opts FormatOptions) (phrase string, sidecar *FormatSidecar, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    phrase, err = Format(rawBytes, tagType, justFirst, byteOrder)
+    log.PanicIf(err)
+
+    if opts.DebugFormat == false && debugFormatEnabled == false {
+        return phrase, nil, nil
+    }
+
+    sidecar = &FormatSidecar{
+        Offset:    opts.Offset,
+        TagType:   tagType,
+        UnitCount: uint32(len(rawBytes) / tagType.Size()),
+        Checksum:  crc32.ChecksumIEEE(rawBytes),
+    }
+
+    return phrase, sidecar, nil
+}
+
+// VerifyDecoded re-derives a sidecar from rawBytes (byteOrder is not
+// consulted) and cross-checks it against a sidecar recorded earlier by
+// FormatEx(), returning an error with a precise description of the first
+// field that disagrees. It's meant to be
+// called right after re-reading a value that was previously decoded with
+// debug-format enabled, to catch a maker-note parser that has drifted out of
+// alignment before it has a chance to produce a plausible-looking-but-wrong
+// value.
+func VerifyDecoded(sidecar FormatSidecar, rawBytes []byte, byteOrder binary.ByteOrder) (err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    unitCount := uint32(len(rawBytes) / sidecar.TagType.Size())
+    checksum := crc32.ChecksumIEEE(rawBytes)
+
+    if unitCount != sidecar.UnitCount {
+        log.Panicf(
+            "out of sync at offset %d: expected %s x%d, got x%d",
+            sidecar.Offset, sidecar.TagType.String(), sidecar.UnitCount, unitCount)
+    }
+
+    if checksum != sidecar.Checksum {
+        log.Panicf(
+            "out of sync at offset %d: expected %s x%d with checksum 0x%x, got checksum 0x%x",
+            sidecar.Offset, sidecar.TagType.String(), sidecar.UnitCount,
+            sidecar.Checksum, checksum)
+    }
+
+    return nil
+}
@@ -0,0 +1,90 @@
+package exifcommon
+
+import (
+    "encoding/binary"
+    "testing"
+)
+
+func TestFormatEx_noSidecarByDefault(t *testing.T) {
+    SetDebugFormat(false)
+
+    raw := []byte{1, 0, 2, 0}
+
+    _, sidecar, err := FormatEx(raw, TypeShort, false, binary.LittleEndian, FormatOptions{})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if sidecar != nil {
+        t.Fatalf("expected no sidecar when debug-format is disabled")
+    }
+}
+
+func TestFormatEx_sidecarViaOptions(t *testing.T) {
+    SetDebugFormat(false)
+
+    raw := []byte{1, 0, 2, 0}
+
+    phrase, sidecar, err := FormatEx(raw, TypeShort, false, binary.LittleEndian, FormatOptions{DebugFormat: true, Offset: 12})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if phrase != "[1 2]" {
+        t.Fatalf("unexpected phrase: [%s]", phrase)
+    }
+
+    if sidecar == nil {
+        t.Fatalf("expected a sidecar when FormatOptions.DebugFormat is true")
+    }
+
+    if sidecar.Offset != 12 || sidecar.TagType != TypeShort || sidecar.UnitCount != 2 {
+        t.Fatalf("unexpected sidecar: %#v", sidecar)
+    }
+}
+
+func TestVerifyDecoded_matchingDataPasses(t *testing.T) {
+    raw := []byte{1, 0, 2, 0}
+
+    _, sidecar, err := FormatEx(raw, TypeShort, false, binary.LittleEndian, FormatOptions{DebugFormat: true})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    err = VerifyDecoded(*sidecar, raw, binary.LittleEndian)
+    if err != nil {
+        t.Fatal(err)
+    }
+}
+
+func TestVerifyDecoded_driftedUnitCountIsDetected(t *testing.T) {
+    raw := []byte{1, 0, 2, 0}
+
+    _, sidecar, err := FormatEx(raw, TypeShort, false, binary.LittleEndian, FormatOptions{DebugFormat: true})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    driftedRaw := []byte{1, 0, 2, 0, 3, 0}
+
+    err = VerifyDecoded(*sidecar, driftedRaw, binary.LittleEndian)
+    if err == nil {
+        t.Fatalf("expected VerifyDecoded to detect a unit-count mismatch")
+    }
+}
+
+func TestVerifyDecoded_driftedChecksumIsDetected(t *testing.T) {
+    raw := []byte{1, 0, 2, 0}
+
+    _, sidecar, err := FormatEx(raw, TypeShort, false, binary.LittleEndian, FormatOptions{DebugFormat: true})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    driftedRaw := []byte{9, 0, 2, 0}
+
+    err = VerifyDecoded(*sidecar, driftedRaw, binary.LittleEndian)
+    if err == nil {
+        t.Fatalf("expected VerifyDecoded to detect a checksum mismatch")
+    }
+}
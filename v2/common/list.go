@@ -0,0 +1,323 @@
+package exifcommon
+
+import (
+    "encoding/csv"
+    "fmt"
+    "strconv"
+    "strings"
+
+    "github.com/dsoprea/go-logging"
+)
+
+// listSeparator is the field separator used by TranslateStringListToType()
+// and FormatList(). It defaults to a comma but can be changed with
+// SetListSeparator(), e.g. for locales or tag conventions that use ';' or a
+// tab as the natural separator.
+var listSeparator = ','
+
+// SetListSeparator changes the field separator used by
+// TranslateStringListToType() and FormatList().
+func SetListSeparator(separator rune) {
+    listSeparator = separator
+}
+
+// TranslateStringListToType parses a CSV-style encoded list of values (one
+// record, comma-separated, double-quoted to embed a comma or a "/") into the
+// slice type appropriate for tagType. It exists because TranslateStringToType
+// only handles a single value, leaving every caller to invent its own
+// quoting/escaping convention for multi-valued tags.
+func TranslateStringListToType(tagType TagTypePrimitive, valueString string) (value interface{}, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if tagType == TypeAscii || tagType == TypeAsciiNoNul || tagType == TypeUndefined {
+        // These aren't list types; hand off to the scalar translator as-is.
+        value, err = TranslateStringToType(tagType, valueString)
+        log.PanicIf(err)
+
+        return value, nil
+    }
+
+    if valueString == "" {
+        return emptyListForType(tagType), nil
+    }
+
+    fields, err := readCsvRecord(valueString)
+    log.PanicIf(err)
+
+    switch tagType {
+    case TypeByte:
+        // TranslateStringToType()'s BYTE branch parses via ParseInt(..., 16,
+        // 8), which rejects anything above 0x7f because it checks against a
+        // *signed* 8-bit range; that's fine for a single scalar but would
+        // make a BYTE list unable to round-trip values above 127. Parse as
+        // unsigned hex directly instead.
+        list := make([]byte, len(fields))
+        for i, field := range fields {
+            n, err := strconv.ParseUint(field, 16, 8)
+            log.PanicIf(err)
+
+            list[i] = byte(n)
+        }
+
+        return list, nil
+    case TypeSignedByte:
+        list := make([]int8, len(fields))
+        for i, field := range fields {
+            v, err := TranslateStringToType(tagType, field)
+            log.PanicIf(err)
+
+            list[i] = v.(int8)
+        }
+
+        return list, nil
+    case TypeShort:
+        list := make([]uint16, len(fields))
+        for i, field := range fields {
+            v, err := TranslateStringToType(tagType, field)
+            log.PanicIf(err)
+
+            list[i] = v.(uint16)
+        }
+
+        return list, nil
+    case TypeSignedShort:
+        list := make([]int16, len(fields))
+        for i, field := range fields {
+            v, err := TranslateStringToType(tagType, field)
+            log.PanicIf(err)
+
+            list[i] = v.(int16)
+        }
+
+        return list, nil
+    case TypeLong:
+        list := make([]uint32, len(fields))
+        for i, field := range fields {
+            v, err := TranslateStringToType(tagType, field)
+            log.PanicIf(err)
+
+            list[i] = v.(uint32)
+        }
+
+        return list, nil
+    case TypeSignedLong:
+        list := make([]int32, len(fields))
+        for i, field := range fields {
+            v, err := TranslateStringToType(tagType, field)
+            log.PanicIf(err)
+
+            list[i] = v.(int32)
+        }
+
+        return list, nil
+    case TypeFloat:
+        list := make([]float32, len(fields))
+        for i, field := range fields {
+            v, err := TranslateStringToType(tagType, field)
+            log.PanicIf(err)
+
+            list[i] = v.(float32)
+        }
+
+        return list, nil
+    case TypeDouble:
+        list := make([]float64, len(fields))
+        for i, field := range fields {
+            v, err := TranslateStringToType(tagType, field)
+            log.PanicIf(err)
+
+            list[i] = v.(float64)
+        }
+
+        return list, nil
+    case TypeRational:
+        list := make([]Rational, len(fields))
+        for i, field := range fields {
+            v, err := TranslateStringToType(tagType, field)
+            log.PanicIf(err)
+
+            list[i] = v.(Rational)
+        }
+
+        return list, nil
+    case TypeSignedRational:
+        list := make([]SignedRational, len(fields))
+        for i, field := range fields {
+            v, err := TranslateStringToType(tagType, field)
+            log.PanicIf(err)
+
+            list[i] = v.(SignedRational)
+        }
+
+        return list, nil
+    }
+
+    log.Panicf("from-string list encoding for type not supported; this shouldn't happen: [%s]", tagType.String())
+    return nil, nil
+}
+
+// emptyListForType returns the correctly-typed nil slice for an empty list,
+// so that FormatList(TranslateStringListToType(tagType, "")) round-trips an
+// empty list through "" instead of erroring on a CSV reader given no input.
+func emptyListForType(tagType TagTypePrimitive) interface{} {
+    switch tagType {
+    case TypeByte:
+        return []byte(nil)
+    case TypeSignedByte:
+        return []int8(nil)
+    case TypeShort:
+        return []uint16(nil)
+    case TypeSignedShort:
+        return []int16(nil)
+    case TypeLong:
+        return []uint32(nil)
+    case TypeSignedLong:
+        return []int32(nil)
+    case TypeFloat:
+        return []float32(nil)
+    case TypeDouble:
+        return []float64(nil)
+    case TypeRational:
+        return []Rational(nil)
+    case TypeSignedRational:
+        return []SignedRational(nil)
+    }
+
+    log.Panicf("from-string list encoding for type not supported; this shouldn't happen: [%s]", tagType.String())
+    return nil
+}
+
+// readCsvRecord parses valueString as a single CSV record using listSeparator
+// as the separator and double-quotes (doubled to escape) to embed a comma or a "/"
+// in a field.
+func readCsvRecord(valueString string) (fields []string, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    r := csv.NewReader(strings.NewReader(valueString))
+    r.Comma = listSeparator
+
+    fields, err = r.Read()
+    log.PanicIf(err)
+
+    return fields, nil
+}
+
+// FormatList is the list counterpart of FormatFromType: it renders a slice of
+// decoded values (as produced by the Parser methods or
+// TranslateStringListToType()) as a
+// single CSV record, quoting any field that contains a comma or a "/" so
+// that it round-trips through TranslateStringListToType() unchanged.
+func FormatList(value interface{}) (valueString string, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    fields, err := scalarStrings(value)
+    log.PanicIf(err)
+
+    quoted := make([]string, len(fields))
+    for i, field := range fields {
+        quoted[i] = quoteCsvField(field, listSeparator)
+    }
+
+    return strings.Join(quoted, string(listSeparator)), nil
+}
+
+// quoteCsvField quotes field (doubling any embedded quote, per standard CSV
+// escaping) if it contains the separator or a character that would
+// otherwise be ambiguous when re-parsed. Unlike encoding/csv's writer, which
+// only quotes when strictly necessary, this also forces quoting on a "/" so
+// that RATIONAL/SRATIONAL fields like "1/2" come back out exactly as they
+// went in rather than relying on the reader tolerating an unquoted "/".
+func quoteCsvField(field string, comma rune) string {
+    needsQuoting := strings.ContainsRune(field, comma) ||
+        strings.ContainsAny(field, "\"\r\n/")
+
+    if needsQuoting == false {
+        return field
+    }
+
+    escaped := strings.ReplaceAll(field, "\"", "\"\"")
+
+    return "\"" + escaped + "\""
+}
+
+// scalarStrings renders each element of a supported slice type to its
+// canonical scalar string form (the same form FormatFromType() uses for a
+// single element), ready to be CSV-quoted and joined.
+func scalarStrings(value interface{}) (fields []string, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    switch t := value.(type) {
+    case []byte:
+        // Matches the hex convention TranslateStringListToType()'s TypeByte
+        // branch parses, so a BYTE list round-trips through FormatList().
+        fields = make([]string, len(t))
+        for i, v := range t {
+            fields[i] = fmt.Sprintf("%x", v)
+        }
+    case []int8:
+        fields = make([]string, len(t))
+        for i, v := range t {
+            fields[i] = fmt.Sprintf("%d", v)
+        }
+    case []uint16:
+        fields = make([]string, len(t))
+        for i, v := range t {
+            fields[i] = fmt.Sprintf("%d", v)
+        }
+    case []int16:
+        fields = make([]string, len(t))
+        for i, v := range t {
+            fields[i] = fmt.Sprintf("%d", v)
+        }
+    case []uint32:
+        fields = make([]string, len(t))
+        for i, v := range t {
+            fields[i] = fmt.Sprintf("%d", v)
+        }
+    case []int32:
+        fields = make([]string, len(t))
+        for i, v := range t {
+            fields[i] = fmt.Sprintf("%d", v)
+        }
+    case []float32:
+        fields = make([]string, len(t))
+        for i, v := range t {
+            fields[i] = fmt.Sprintf("%v", v)
+        }
+    case []float64:
+        fields = make([]string, len(t))
+        for i, v := range t {
+            fields[i] = fmt.Sprintf("%v", v)
+        }
+    case []Rational:
+        fields = make([]string, len(t))
+        for i, v := range t {
+            fields[i] = fmt.Sprintf("%d/%d", v.Numerator, v.Denominator)
+        }
+    case []SignedRational:
+        fields = make([]string, len(t))
+        for i, v := range t {
+            fields[i] = fmt.Sprintf("%d/%d", v.Numerator, v.Denominator)
+        }
+    default:
+        log.Panicf("type can not be formatted into a CSV list: %v", value)
+    }
+
+    return fields, nil
+}
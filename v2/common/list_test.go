@@ -0,0 +1,88 @@
+package exifcommon
+
+import (
+    "reflect"
+    "testing"
+)
+
+func TestTranslateStringListToType_rational(t *testing.T) {
+    value, err := TranslateStringListToType(TypeRational, `"1/2","3/4","5/6"`)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    expected := []Rational{
+        {Numerator: 1, Denominator: 2},
+        {Numerator: 3, Denominator: 4},
+        {Numerator: 5, Denominator: 6},
+    }
+
+    if reflect.DeepEqual(value, expected) != true {
+        t.Fatalf("expected (%v), got (%v)", expected, value)
+    }
+}
+
+func TestFormatList_rationalRoundTrip(t *testing.T) {
+    original := `"1/2","3/4","5/6"`
+
+    value, err := TranslateStringListToType(TypeRational, original)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    formatted, err := FormatList(value)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if formatted != original {
+        t.Fatalf("expected round-trip to reproduce (%s), got (%s)", original, formatted)
+    }
+}
+
+func TestTranslateStringListToType_byteFullRange(t *testing.T) {
+    value, err := TranslateStringListToType(TypeByte, "a,14,ff")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    expected := []byte{0x0a, 0x14, 0xff}
+
+    if reflect.DeepEqual(value, expected) != true {
+        t.Fatalf("expected (%v), got (%v)", expected, value)
+    }
+}
+
+func TestFormatList_byteRoundTripFullRange(t *testing.T) {
+    original := []byte{10, 20, 255}
+
+    formatted, err := FormatList(original)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    value, err := TranslateStringListToType(TypeByte, formatted)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if reflect.DeepEqual(value, original) != true {
+        t.Fatalf("expected round-trip (%v), got (%v) via intermediate string [%s]", original, value, formatted)
+    }
+}
+
+func TestTranslateStringListToType_emptyRoundTrips(t *testing.T) {
+    value, err := TranslateStringListToType(TypeLong, "")
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    formatted, err := FormatList(value)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if formatted != "" {
+        t.Fatalf("expected empty list to format back to \"\", got [%s]", formatted)
+    }
+}
@@ -0,0 +1,273 @@
+package exifcommon
+
+import (
+    "encoding/binary"
+    "math"
+
+    "github.com/dsoprea/go-logging"
+)
+
+var (
+    parserLogger = log.NewLogger("exifcommon.parser")
+)
+
+// Parser knows how to parse all well-defined, encoded EXIF types.
+type Parser struct {
+}
+
+// ParseBytes knows how to parse a byte-type value.
+func (parser Parser) ParseBytes(data []byte, unitCount uint32) (value []uint8, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(data) < int(unitCount) {
+        log.Panic(ErrNotEnoughData)
+    }
+
+    value = []uint8(data[:unitCount])
+
+    return value, nil
+}
+
+// ParseAscii returns a string and auto-strips the trailing NUL character that
+// should be at the end of the encoding.
+func (parser Parser) ParseAscii(data []byte, unitCount uint32) (value string, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(data) < int(unitCount) {
+        log.Panic(ErrNotEnoughData)
+    }
+
+    if unitCount == 0 {
+        return "", nil
+    }
+
+    b := data[:unitCount]
+
+    if b[unitCount-1] != 0 {
+        s := string(b)
+        parserLogger.Warningf(nil, "ASCII value not terminated with a NUL byte: [%v]", s)
+
+        return s, nil
+    }
+
+    return string(b[:unitCount-1]), nil
+}
+
+// ParseAsciiNoNul returns a string and does not expect a trailing NUL
+// character.
+func (parser Parser) ParseAsciiNoNul(data []byte, unitCount uint32) (value string, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(data) < int(unitCount) {
+        log.Panic(ErrNotEnoughData)
+    }
+
+    return string(data[:unitCount]), nil
+}
+
+// ParseShorts knows how to parse an encoded list of shorts.
+func (parser Parser) ParseShorts(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []uint16, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(data) < int(unitCount)*2 {
+        log.Panic(ErrNotEnoughData)
+    }
+
+    value = make([]uint16, unitCount)
+    for i := uint32(0); i < unitCount; i++ {
+        value[i] = byteOrder.Uint16(data[i*2:])
+    }
+
+    return value, nil
+}
+
+// ParseLongs knows how to parse an encoded list of longs.
+func (parser Parser) ParseLongs(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []uint32, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(data) < int(unitCount)*4 {
+        log.Panic(ErrNotEnoughData)
+    }
+
+    value = make([]uint32, unitCount)
+    for i := uint32(0); i < unitCount; i++ {
+        value[i] = byteOrder.Uint32(data[i*4:])
+    }
+
+    return value, nil
+}
+
+// ParseRationals knows how to parse an encoded list of unsigned rationals.
+func (parser Parser) ParseRationals(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []Rational, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(data) < int(unitCount)*8 {
+        log.Panic(ErrNotEnoughData)
+    }
+
+    value = make([]Rational, unitCount)
+    for i := uint32(0); i < unitCount; i++ {
+        value[i] = Rational{
+            Numerator:   byteOrder.Uint32(data[i*8:]),
+            Denominator: byteOrder.Uint32(data[i*8+4:]),
+        }
+    }
+
+    return value, nil
+}
+
+// ParseSignedLongs knows how to parse an encoded list of signed longs.
+func (parser Parser) ParseSignedLongs(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []int32, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(data) < int(unitCount)*4 {
+        log.Panic(ErrNotEnoughData)
+    }
+
+    value = make([]int32, unitCount)
+    for i := uint32(0); i < unitCount; i++ {
+        value[i] = int32(byteOrder.Uint32(data[i*4:]))
+    }
+
+    return value, nil
+}
+
+// ParseSignedRationals knows how to parse an encoded list of signed
+// rationals.
+func (parser Parser) ParseSignedRationals(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []SignedRational, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(data) < int(unitCount)*8 {
+        log.Panic(ErrNotEnoughData)
+    }
+
+    value = make([]SignedRational, unitCount)
+    for i := uint32(0); i < unitCount; i++ {
+        value[i] = SignedRational{
+            Numerator:   int32(byteOrder.Uint32(data[i*8:])),
+            Denominator: int32(byteOrder.Uint32(data[i*8+4:])),
+        }
+    }
+
+    return value, nil
+}
+
+// ParseSignedBytes knows how to parse an encoded list of signed bytes.
+func (parser Parser) ParseSignedBytes(data []byte, unitCount uint32) (value []int8, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(data) < int(unitCount) {
+        log.Panic(ErrNotEnoughData)
+    }
+
+    value = make([]int8, unitCount)
+    for i := uint32(0); i < unitCount; i++ {
+        value[i] = int8(data[i])
+    }
+
+    return value, nil
+}
+
+// ParseSignedShorts knows how to parse an encoded list of signed shorts.
+func (parser Parser) ParseSignedShorts(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []int16, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(data) < int(unitCount)*2 {
+        log.Panic(ErrNotEnoughData)
+    }
+
+    value = make([]int16, unitCount)
+    for i := uint32(0); i < unitCount; i++ {
+        value[i] = int16(byteOrder.Uint16(data[i*2:]))
+    }
+
+    return value, nil
+}
+
+// ParseFloats knows how to parse an encoded list of IEEE-754 single-precision
+// floats.
+func (parser Parser) ParseFloats(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []float32, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(data) < int(unitCount)*4 {
+        log.Panic(ErrNotEnoughData)
+    }
+
+    value = make([]float32, unitCount)
+    for i := uint32(0); i < unitCount; i++ {
+        bits := byteOrder.Uint32(data[i*4:])
+        value[i] = math.Float32frombits(bits)
+    }
+
+    return value, nil
+}
+
+// ParseDoubles knows how to parse an encoded list of IEEE-754
+// double-precision floats.
+func (parser Parser) ParseDoubles(data []byte, unitCount uint32, byteOrder binary.ByteOrder) (value []float64, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(data) < int(unitCount)*8 {
+        log.Panic(ErrNotEnoughData)
+    }
+
+    value = make([]float64, unitCount)
+    for i := uint32(0); i < unitCount; i++ {
+        bits := byteOrder.Uint64(data[i*8:])
+        value[i] = math.Float64frombits(bits)
+    }
+
+    return value, nil
+}
+
+var (
+    parser = Parser{}
+)
@@ -0,0 +1,84 @@
+package strip
+
+import (
+    "encoding/binary"
+    "math"
+
+    "github.com/dsoprea/go-logging"
+
+    exifcommon "github.com/dsoprea/go-exif/v2/common"
+)
+
+// encodeReplacement turns a caller-provided replacement string into raw,
+// type-correct bytes for the given tag, using exifcommon's existing
+// string-to-type parsing so that callers get the same syntax/semantics as
+// everywhere else in the library (e.g. "1/2" for a RATIONAL).
+func encodeReplacement(tagType exifcommon.TagTypePrimitive, valueString string, byteOrder binary.ByteOrder) (raw []byte, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    value, err := exifcommon.TranslateStringToType(tagType, valueString)
+    log.PanicIf(err)
+
+    switch t := value.(type) {
+    case byte:
+        return []byte{t}, nil
+    case int8:
+        return []byte{byte(t)}, nil
+    case string:
+        raw := make([]byte, len(t)+1)
+        copy(raw, t)
+
+        return raw, nil
+    case uint16:
+        raw := make([]byte, 2)
+        byteOrder.PutUint16(raw, t)
+
+        return raw, nil
+    case int16:
+        raw := make([]byte, 2)
+        byteOrder.PutUint16(raw, uint16(t))
+
+        return raw, nil
+    case uint32:
+        raw := make([]byte, 4)
+        byteOrder.PutUint32(raw, t)
+
+        return raw, nil
+    case int32:
+        raw := make([]byte, 4)
+        byteOrder.PutUint32(raw, uint32(t))
+
+        return raw, nil
+    case float32:
+        raw := make([]byte, 4)
+        byteOrder.PutUint32(raw, math.Float32bits(t))
+
+        return raw, nil
+    case float64:
+        raw := make([]byte, 8)
+        byteOrder.PutUint64(raw, math.Float64bits(t))
+
+        return raw, nil
+    case exifcommon.Rational:
+        raw := make([]byte, 8)
+        byteOrder.PutUint32(raw[0:4], t.Numerator)
+        byteOrder.PutUint32(raw[4:8], t.Denominator)
+
+        return raw, nil
+    case exifcommon.SignedRational:
+        raw := make([]byte, 8)
+        byteOrder.PutUint32(raw[0:4], uint32(t.Numerator))
+        byteOrder.PutUint32(raw[4:8], uint32(t.Denominator))
+
+        return raw, nil
+    default:
+        log.Panicf("replacement value can not be encoded: %v", value)
+
+        // Never called.
+        return nil, nil
+    }
+}
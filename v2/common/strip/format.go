@@ -0,0 +1,39 @@
+package strip
+
+// maxChunkPayloadSize bounds how large a single PNG chunk or WebP chunk
+// payload we're willing to allocate for, based on the length field read off
+// the wire. Those length fields are attacker-controlled input, so we refuse
+// to `make([]byte, length)` for an implausibly large claimed length before
+// we've read a single byte of it.
+const maxChunkPayloadSize = 100 * 1024 * 1024
+
+// isJpeg returns true if the peeked header looks like a JPEG SOI marker.
+func isJpeg(header []byte) bool {
+    return len(header) >= 2 && header[0] == 0xff && header[1] == 0xd8
+}
+
+// isPng returns true if the peeked header matches the PNG file signature.
+func isPng(header []byte) bool {
+    signature := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+    if len(header) < len(signature) {
+        return false
+    }
+
+    for i, b := range signature {
+        if header[i] != b {
+            return false
+        }
+    }
+
+    return true
+}
+
+// isWebp returns true if the peeked header matches a RIFF/WEBP container.
+func isWebp(header []byte) bool {
+    if len(header) < 12 {
+        return false
+    }
+
+    return string(header[0:4]) == "RIFF" && string(header[8:12]) == "WEBP"
+}
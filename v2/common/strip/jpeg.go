@@ -0,0 +1,153 @@
+package strip
+
+import (
+    "bufio"
+    "encoding/binary"
+    "io"
+
+    "github.com/dsoprea/go-logging"
+)
+
+const (
+    jpegMarkerStart = 0xff
+    jpegMarkerSoi   = 0xd8
+    jpegMarkerSos   = 0xda
+    jpegMarkerEoi   = 0xd9
+    jpegMarkerApp1  = 0xe1
+)
+
+var (
+    exifPrefix = []byte("Exif\x00\x00")
+)
+
+// stripJpeg walks JPEG markers, rewriting the APP1/Exif segment (if any) in
+// place and passing every other marker, and the final compressed scan data,
+// straight through.
+func stripJpeg(br *bufio.Reader, out io.Writer, opts StripOptions) (err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    soi := make([]byte, 2)
+
+    _, err = io.ReadFull(br, soi)
+    log.PanicIf(err)
+
+    _, err = out.Write(soi)
+    log.PanicIf(err)
+
+    for {
+        marker, err := readMarker(br)
+        log.PanicIf(err)
+
+        if marker == jpegMarkerEoi {
+            _, err := out.Write([]byte{jpegMarkerStart, marker})
+            log.PanicIf(err)
+
+            break
+        }
+
+        if marker == jpegMarkerSos {
+            _, err := out.Write([]byte{jpegMarkerStart, marker})
+            log.PanicIf(err)
+
+            // Everything from here to EOI is entropy-coded scan data; copy
+            // it through verbatim rather than trying to parse it.
+            _, err = io.Copy(out, br)
+            log.PanicIf(err)
+
+            return nil
+        }
+
+        // Markers with no payload (RSTn, TEM, and a handful of others) are
+        // not preceded by a length.
+        if isPayloadlessMarker(marker) {
+            _, err := out.Write([]byte{jpegMarkerStart, marker})
+            log.PanicIf(err)
+
+            continue
+        }
+
+        lengthBytes := make([]byte, 2)
+
+        _, err = io.ReadFull(br, lengthBytes)
+        log.PanicIf(err)
+
+        length := binary.BigEndian.Uint16(lengthBytes)
+
+        payload := make([]byte, int(length)-2)
+
+        _, err = io.ReadFull(br, payload)
+        log.PanicIf(err)
+
+        if marker == jpegMarkerApp1 && hasExifPrefix(payload) {
+            tiffBlob := payload[len(exifPrefix):]
+
+            err := rewriteTiff(tiffBlob, opts)
+            log.PanicIf(err)
+        }
+
+        _, err = out.Write([]byte{jpegMarkerStart, marker})
+        log.PanicIf(err)
+
+        _, err = out.Write(lengthBytes)
+        log.PanicIf(err)
+
+        _, err = out.Write(payload)
+        log.PanicIf(err)
+    }
+
+    return nil
+}
+
+// readMarker scans past any fill bytes (0xff padding) and returns the marker
+// byte that follows the 0xff that introduces it.
+func readMarker(br *bufio.Reader) (marker byte, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    b, err := br.ReadByte()
+    log.PanicIf(err)
+
+    if b != jpegMarkerStart {
+        log.Panicf("expected marker-introducer 0xff, got 0x%x", b)
+    }
+
+    for {
+        m, err := br.ReadByte()
+        log.PanicIf(err)
+
+        if m != jpegMarkerStart {
+            return m, nil
+        }
+
+        // Fill byte; keep scanning.
+    }
+}
+
+func isPayloadlessMarker(marker byte) bool {
+    if marker >= 0xd0 && marker <= 0xd7 {
+        return true
+    }
+
+    return marker == 0x01 || marker == jpegMarkerSoi
+}
+
+func hasExifPrefix(payload []byte) bool {
+    if len(payload) < len(exifPrefix) {
+        return false
+    }
+
+    for i, b := range exifPrefix {
+        if payload[i] != b {
+            return false
+        }
+    }
+
+    return true
+}
@@ -0,0 +1,137 @@
+package strip
+
+import (
+    "bufio"
+    "bytes"
+    "encoding/binary"
+    "hash/crc32"
+    "io"
+    "strings"
+
+    "github.com/dsoprea/go-logging"
+)
+
+var (
+    pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+)
+
+const (
+    pngChunkTypeExif = "eXIf"
+    pngChunkTypeText = "tEXt"
+    pngChunkTypeItxt = "iTXt"
+)
+
+// stripPng walks PNG chunks, rewriting the eXIf chunk (if any) in place and
+// dropping any text chunk whose keyword identifies it as carrying EXIF data
+// when opts.DropAll is set, passing everything else through verbatim.
+func stripPng(br *bufio.Reader, out io.Writer, opts StripOptions) (err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    signature := make([]byte, len(pngSignature))
+
+    _, err = io.ReadFull(br, signature)
+    log.PanicIf(err)
+
+    _, err = out.Write(signature)
+    log.PanicIf(err)
+
+    for {
+        lengthBytes := make([]byte, 4)
+
+        _, err := io.ReadFull(br, lengthBytes)
+        if err == io.EOF {
+            return nil
+        }
+
+        log.PanicIf(err)
+
+        length := binary.BigEndian.Uint32(lengthBytes)
+        if length > maxChunkPayloadSize {
+            log.Panicf("PNG chunk claims a payload of %d bytes, over the %d-byte cap", length, maxChunkPayloadSize)
+        }
+
+        chunkType := make([]byte, 4)
+
+        _, err = io.ReadFull(br, chunkType)
+        log.PanicIf(err)
+
+        data := make([]byte, length)
+
+        _, err = io.ReadFull(br, data)
+        log.PanicIf(err)
+
+        crcBytes := make([]byte, 4)
+
+        _, err = io.ReadFull(br, crcBytes)
+        log.PanicIf(err)
+
+        chunkTypeString := string(chunkType)
+
+        if chunkTypeString == pngChunkTypeExif {
+            err := rewriteTiff(data, opts)
+            log.PanicIf(err)
+
+            crc := crc32.ChecksumIEEE(append(append([]byte{}, chunkType...), data...))
+            binary.BigEndian.PutUint32(crcBytes, crc)
+        } else if opts.DropAll == true && isExifTextChunk(chunkTypeString, data) {
+            // A text chunk that's only there to carry EXIF metadata; drop it
+            // entirely rather than trying to zero individual tags out of its
+            // ad-hoc encoding.
+            continue
+        }
+
+        err = writeChunk(out, lengthBytes, chunkType, data, crcBytes)
+        log.PanicIf(err)
+
+        if chunkTypeString == "IEND" {
+            _, err = io.Copy(out, br)
+            log.PanicIf(err)
+
+            return nil
+        }
+    }
+}
+
+func writeChunk(out io.Writer, lengthBytes, chunkType, data, crcBytes []byte) (err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    _, err = out.Write(lengthBytes)
+    log.PanicIf(err)
+
+    _, err = out.Write(chunkType)
+    log.PanicIf(err)
+
+    _, err = out.Write(data)
+    log.PanicIf(err)
+
+    _, err = out.Write(crcBytes)
+    log.PanicIf(err)
+
+    return nil
+}
+
+// isExifTextChunk recognizes the ImageMagick/ExifTool convention of stashing
+// raw EXIF inside a tEXt/iTXt chunk under a keyword like "Raw profile type
+// exif".
+func isExifTextChunk(chunkType string, data []byte) bool {
+    if chunkType != pngChunkTypeText && chunkType != pngChunkTypeItxt {
+        return false
+    }
+
+    keywordEnd := bytes.IndexByte(data, 0)
+    if keywordEnd == -1 {
+        return false
+    }
+
+    keyword := strings.ToLower(string(data[:keywordEnd]))
+
+    return strings.Contains(keyword, "exif")
+}
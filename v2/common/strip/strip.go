@@ -0,0 +1,116 @@
+// Package strip implements a streaming, container-aware EXIF stripper and
+// rewriter. Unlike decoding an image and re-encoding it, it walks the
+// container structure (JPEG markers, PNG chunks, RIFF/WebP chunks) and
+// rewrites only the segments that carry EXIF/IFD data, copying everything
+// else through untouched. This preserves bit-for-bit fidelity of the pixel
+// data and any metadata the caller didn't ask to touch.
+package strip
+
+import (
+    "bufio"
+    "errors"
+    "io"
+
+    "github.com/dsoprea/go-logging"
+)
+
+var (
+    // ErrUnsupportedFormat is returned when the input doesn't look like a
+    // JPEG, PNG, or WebP stream.
+    ErrUnsupportedFormat = errors.New("unsupported image format for stripping")
+)
+
+// TagKey identifies a single tag within a specific IFD, which is what's
+// required to either keep or replace it independent of its position in the
+// tag stream.
+type TagKey struct {
+    IfdPath string
+    TagId   uint16
+}
+
+// StripOptions controls what Strip() does with EXIF data that it finds.
+type StripOptions struct {
+    // DropAll, if true, zeroes the value of every EXIF/IFD tag encountered
+    // except those listed in Keep. Replace still applies.
+    DropAll bool
+
+    // Keep is an allowlist of tags that should survive a DropAll pass. It is
+    // ignored unless DropAll is true.
+    Keep []TagKey
+
+    // Replace maps a tag to a replacement value, encoded as a string in the
+    // same format accepted by exifcommon.TranslateStringToType(). The tag's
+    // type is not changed, only its value.
+    Replace map[TagKey]string
+}
+
+// keep returns true if the given tag should be preserved as-is given the
+// configured options.
+func (so StripOptions) keep(ifdPath string, tagId uint16) bool {
+    if so.DropAll == false {
+        return true
+    }
+
+    for _, tk := range so.Keep {
+        if tk.IfdPath == ifdPath && tk.TagId == tagId {
+            return true
+        }
+    }
+
+    return false
+}
+
+// replacement returns the replacement string for the given tag, if any was
+// configured.
+func (so StripOptions) replacement(ifdPath string, tagId uint16) (valueString string, found bool) {
+    if so.Replace == nil {
+        return "", false
+    }
+
+    valueString, found = so.Replace[TagKey{IfdPath: ifdPath, TagId: tagId}]
+
+    return valueString, found
+}
+
+// Strip reads an image from `in` and writes a copy to `out` with EXIF/IFD
+// data removed, filtered, or replaced according to `opts`. It never
+// materializes the whole image in memory; it streams marker-by-marker (JPEG)
+// or chunk-by-chunk (PNG, WebP), copying anything it doesn't recognize
+// through verbatim.
+func Strip(in io.Reader, out io.Writer, opts StripOptions) (err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    br := bufio.NewReader(in)
+
+    header, err := br.Peek(12)
+    if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+        log.Panic(err)
+    }
+
+    if isJpeg(header) {
+        err := stripJpeg(br, out, opts)
+        log.PanicIf(err)
+
+        return nil
+    } else if isPng(header) {
+        err := stripPng(br, out, opts)
+        log.PanicIf(err)
+
+        return nil
+    } else if isWebp(header) {
+        err := stripWebp(br, out, opts)
+        log.PanicIf(err)
+
+        return nil
+    }
+
+    log.Panic(ErrUnsupportedFormat)
+
+    // Never called.
+    return nil
+}
+
@@ -0,0 +1,76 @@
+package strip
+
+import (
+    "bytes"
+    "encoding/binary"
+    "testing"
+    "time"
+)
+
+// buildSelfReferencingTiff builds a minimal little-endian TIFF blob whose
+// IFD0 "next IFD" pointer points back at IFD0 itself.
+func buildSelfReferencingTiff() []byte {
+    // Header (8 bytes) + one IFD: 2-byte entry-count (0 entries) + 4-byte
+    // next-IFD pointer.
+    blob := make([]byte, 8+2+4)
+
+    copy(blob[0:2], []byte("II"))
+    binary.LittleEndian.PutUint16(blob[2:4], 42)
+    binary.LittleEndian.PutUint32(blob[4:8], 8)
+
+    binary.LittleEndian.PutUint16(blob[8:10], 0)
+    binary.LittleEndian.PutUint32(blob[10:14], 8)
+
+    return blob
+}
+
+func TestRewriteTiff_cycleIsDetectedNotHung(t *testing.T) {
+    blob := buildSelfReferencingTiff()
+
+    done := make(chan error, 1)
+
+    go func() {
+        done <- rewriteTiff(blob, StripOptions{})
+    }()
+
+    select {
+    case err := <-done:
+        if err == nil {
+            t.Fatalf("expected an error for a self-referencing IFD chain, got nil")
+        }
+    case <-time.After(2 * time.Second):
+        t.Fatalf("rewriteTiff did not return within 2s; it appears to have looped")
+    }
+}
+
+func TestIsJpeg(t *testing.T) {
+    if isJpeg([]byte{0xff, 0xd8, 0xff, 0xe0}) != true {
+        t.Fatalf("expected JPEG header to be recognized")
+    }
+
+    if isJpeg([]byte{0x89, 'P', 'N', 'G'}) != false {
+        t.Fatalf("did not expect a PNG header to be recognized as JPEG")
+    }
+}
+
+func TestStrip_jpegWithoutExifPassesThrough(t *testing.T) {
+    // SOI, a harmless APP0/JFIF segment with no payload content we care
+    // about, then SOS-less EOI (not a real decodable JPEG, but enough to
+    // exercise the marker walk).
+    in := []byte{
+        0xff, 0xd8, // SOI
+        0xff, 0xe0, 0x00, 0x04, 0x4a, 0x46, // APP0, length 4, payload "JF"
+        0xff, 0xd9, // EOI
+    }
+
+    out := new(bytes.Buffer)
+
+    err := Strip(bytes.NewReader(in), out, StripOptions{DropAll: true})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if bytes.Equal(out.Bytes(), in) != true {
+        t.Fatalf("expected passthrough output to be byte-identical to input:\n in=%x\nout=%x", in, out.Bytes())
+    }
+}
@@ -0,0 +1,229 @@
+package strip
+
+import (
+    "encoding/binary"
+    "fmt"
+
+    "github.com/dsoprea/go-logging"
+
+    exifcommon "github.com/dsoprea/go-exif/v2/common"
+)
+
+const (
+    exifSubIfdTagId = 0x8769
+    gpsIfdTagId     = 0x8825
+
+    // maxIfds bounds the total number of IFDs a single TIFF blob can walk
+    // (primary chain plus every Exif/GPS sub-IFD). It's far more than any
+    // legitimate image needs, and exists purely to bound the work done on
+    // untrusted input.
+    maxIfds = 256
+)
+
+// ifdWalk tracks state shared across an entire TIFF blob's walk so that a
+// "next IFD" pointer (or an Exif/GPS sub-IFD pointer) that loops back to an
+// already-visited offset is detected instead of being followed forever.
+type ifdWalk struct {
+    visited map[uint32]bool
+    count   int
+}
+
+// visit records ifdOffset as visited, returning an error if it has already
+// been visited (a cycle) or if the walk has exceeded maxIfds.
+func (w *ifdWalk) visit(ifdOffset uint32) error {
+    if w.visited[ifdOffset] {
+        return fmt.Errorf("cycle detected in IFD chain at offset 0x%x", ifdOffset)
+    }
+
+    w.count++
+    if w.count > maxIfds {
+        return fmt.Errorf("too many IFDs in TIFF blob (max %d)", maxIfds)
+    }
+
+    w.visited[ifdOffset] = true
+
+    return nil
+}
+
+// rewriteTiff mutates a raw TIFF-formatted EXIF blob (header plus IFDs) in
+// place, zeroing or replacing tag values per `opts`. It's intentionally
+// shallow compared to a full IFD-tree decoder: it only needs enough of the
+// structure (byte-order, IFD entry layout, the Exif/GPS sub-IFD pointers) to
+// locate every tag value, not to build a navigable tree out of them.
+func rewriteTiff(blob []byte, opts StripOptions) (err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    if len(blob) < 8 {
+        log.Panicf("TIFF blob too short to contain a header")
+    }
+
+    var byteOrder binary.ByteOrder
+
+    switch string(blob[0:2]) {
+    case "II":
+        byteOrder = binary.LittleEndian
+    case "MM":
+        byteOrder = binary.BigEndian
+    default:
+        log.Panicf("not a valid TIFF byte-order marker: [%s]", string(blob[0:2]))
+    }
+
+    firstIfdOffset := byteOrder.Uint32(blob[4:8])
+
+    walk := &ifdWalk{visited: map[uint32]bool{}}
+
+    err = rewriteIfdChain(blob, firstIfdOffset, "IFD", byteOrder, opts, walk)
+    log.PanicIf(err)
+
+    return nil
+}
+
+// rewriteIfdChain walks a linked list of IFDs (following the "next IFD"
+// pointer at the end of each one), rewriting tags in each and recursing into
+// the Exif and GPS sub-IFDs when their pointer tags are present.
+func rewriteIfdChain(blob []byte, ifdOffset uint32, ifdPath string, byteOrder binary.ByteOrder, opts StripOptions, walk *ifdWalk) (err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    for ifdOffset != 0 {
+        nextIfdOffset, err := rewriteIfd(blob, ifdOffset, ifdPath, byteOrder, opts, walk)
+        log.PanicIf(err)
+
+        ifdOffset = nextIfdOffset
+    }
+
+    return nil
+}
+
+// rewriteIfd rewrites the tags of a single IFD and returns the offset of the
+// next IFD in the chain (0 if there isn't one).
+func rewriteIfd(blob []byte, ifdOffset uint32, ifdPath string, byteOrder binary.ByteOrder, opts StripOptions, walk *ifdWalk) (nextIfdOffset uint32, err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    err = walk.visit(ifdOffset)
+    log.PanicIf(err)
+
+    if int(ifdOffset)+2 > len(blob) {
+        log.Panicf("IFD offset (0x%x) is out of bounds", ifdOffset)
+    }
+
+    entryCount := int(byteOrder.Uint16(blob[ifdOffset : ifdOffset+2]))
+
+    cursor := int(ifdOffset) + 2
+
+    for i := 0; i < entryCount; i++ {
+        entry := blob[cursor : cursor+12]
+
+        tagId := byteOrder.Uint16(entry[0:2])
+        tagType := exifcommon.TagTypePrimitive(byteOrder.Uint16(entry[2:4]))
+        unitCount := byteOrder.Uint32(entry[4:8])
+
+        if tagId == exifSubIfdTagId || tagId == gpsIfdTagId {
+            // Recurse into the sub-IFD before rewriteEntry() below has a
+            // chance to zero out this entry's own value field, which is the
+            // sub-IFD's offset.
+            subIfdOffset := byteOrder.Uint32(blob[cursor+8 : cursor+12])
+
+            var subIfdPath string
+            if tagId == exifSubIfdTagId {
+                subIfdPath = ifdPath + "/Exif"
+            } else {
+                subIfdPath = ifdPath + "/GPS"
+            }
+
+            err := rewriteIfdChain(blob, subIfdOffset, subIfdPath, byteOrder, opts, walk)
+            log.PanicIf(err)
+        }
+
+        err := rewriteEntry(blob, cursor, tagId, tagType, unitCount, ifdPath, byteOrder, opts)
+        log.PanicIf(err)
+
+        cursor += 12
+    }
+
+    nextIfdOffset = byteOrder.Uint32(blob[cursor : cursor+4])
+
+    return nextIfdOffset, nil
+}
+
+// rewriteEntry applies the keep/replace policy to a single IFD entry's
+// value, which may be stored inline (<=4 bytes) or out-of-line via an
+// offset elsewhere in the same blob.
+func rewriteEntry(blob []byte, entryOffset int, tagId uint16, tagType exifcommon.TagTypePrimitive, unitCount uint32, ifdPath string, byteOrder binary.ByteOrder, opts StripOptions) (err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    valueFieldOffset := entryOffset + 8
+
+    var valueSize int
+    if tagType == exifcommon.TypeUndefined {
+        // UNDEFINED values are counted in bytes, but Size() has no entry for
+        // them.
+        valueSize = int(unitCount)
+    } else if tagType.IsValid() == true {
+        valueSize = tagType.Size() * int(unitCount)
+    } else {
+        // Unknown type; treat the 4-byte value field itself as the extent of
+        // what we're willing to touch.
+        valueSize = 4
+    }
+
+    isInline := valueSize <= 4
+
+    var valueBytes []byte
+    if isInline {
+        valueBytes = blob[valueFieldOffset : valueFieldOffset+4]
+    } else {
+        dataOffset := int(byteOrder.Uint32(blob[valueFieldOffset : valueFieldOffset+4]))
+        if dataOffset+valueSize > len(blob) {
+            // Corrupt/truncated pointer; nothing safe to do here.
+            return nil
+        }
+
+        valueBytes = blob[dataOffset : dataOffset+valueSize]
+    }
+
+    if valueString, found := opts.replacement(ifdPath, tagId); found == true {
+        raw, err := encodeReplacement(tagType, valueString, byteOrder)
+        log.PanicIf(err)
+
+        n := len(raw)
+        if n > len(valueBytes) {
+            n = len(valueBytes)
+        }
+
+        copy(valueBytes, raw[:n])
+
+        // A shorter replacement must not leave a tail of the original value
+        // sitting in the file.
+        for i := n; i < len(valueBytes); i++ {
+            valueBytes[i] = 0
+        }
+
+        return nil
+    }
+
+    if opts.keep(ifdPath, tagId) == true {
+        return nil
+    }
+
+    for i := range valueBytes {
+        valueBytes[i] = 0
+    }
+
+    return nil
+}
@@ -0,0 +1,71 @@
+package strip
+
+import (
+    "bufio"
+    "encoding/binary"
+    "io"
+
+    "github.com/dsoprea/go-logging"
+)
+
+const (
+    webpChunkTypeExif = "EXIF"
+)
+
+// stripWebp walks a RIFF/WEBP container's chunks, rewriting the EXIF chunk
+// (if any) in place and passing everything else through verbatim.
+func stripWebp(br *bufio.Reader, out io.Writer, opts StripOptions) (err error) {
+    defer func() {
+        if state := recover(); state != nil {
+            err = log.Wrap(state.(error))
+        }
+    }()
+
+    header := make([]byte, 12)
+
+    _, err = io.ReadFull(br, header)
+    log.PanicIf(err)
+
+    _, err = out.Write(header)
+    log.PanicIf(err)
+
+    for {
+        chunkHeader := make([]byte, 8)
+
+        _, err := io.ReadFull(br, chunkHeader)
+        if err == io.EOF || err == io.ErrUnexpectedEOF {
+            return nil
+        }
+
+        log.PanicIf(err)
+
+        fourCc := string(chunkHeader[0:4])
+        size := binary.LittleEndian.Uint32(chunkHeader[4:8])
+
+        if size > maxChunkPayloadSize {
+            log.Panicf("WebP chunk claims a payload of %d bytes, over the %d-byte cap", size, maxChunkPayloadSize)
+        }
+
+        // Chunks are padded to an even number of bytes.
+        paddedSize := size
+        if paddedSize%2 == 1 {
+            paddedSize++
+        }
+
+        data := make([]byte, paddedSize)
+
+        _, err = io.ReadFull(br, data)
+        log.PanicIf(err)
+
+        if fourCc == webpChunkTypeExif {
+            err := rewriteTiff(data[:size], opts)
+            log.PanicIf(err)
+        }
+
+        _, err = out.Write(chunkHeader)
+        log.PanicIf(err)
+
+        _, err = out.Write(data)
+        log.PanicIf(err)
+    }
+}
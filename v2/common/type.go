@@ -51,16 +51,30 @@ const (
     // TypeRational describes an encoded list of rationals.
     TypeRational TagTypePrimitive = 5
 
+    // TypeSignedByte describes an encoded list of signed bytes.
+    TypeSignedByte TagTypePrimitive = 6
+
     // TypeUndefined describes an encoded value that has a complex/non-clearcut
     // interpretation.
     TypeUndefined TagTypePrimitive = 7
 
+    // TypeSignedShort describes an encoded list of signed shorts.
+    TypeSignedShort TagTypePrimitive = 8
+
     // TypeSignedLong describes an encoded list of signed longs.
     TypeSignedLong TagTypePrimitive = 9
 
     // TypeSignedRational describes an encoded list of signed rationals.
     TypeSignedRational TagTypePrimitive = 10
 
+    // TypeFloat describes an encoded list of IEEE-754 single-precision
+    // floats.
+    TypeFloat TagTypePrimitive = 11
+
+    // TypeDouble describes an encoded list of IEEE-754 double-precision
+    // floats.
+    TypeDouble TagTypePrimitive = 12
+
     // TypeAsciiNoNul is just a pseudo-type, for our own purposes.
     TypeAsciiNoNul TagTypePrimitive = 0xf0
 )
@@ -82,10 +96,18 @@ func (tagType TagTypePrimitive) Size() int {
         return 4
     } else if tagType == TypeRational {
         return 8
+    } else if tagType == TypeSignedByte {
+        return 1
+    } else if tagType == TypeSignedShort {
+        return 2
     } else if tagType == TypeSignedLong {
         return 4
     } else if tagType == TypeSignedRational {
         return 8
+    } else if tagType == TypeFloat {
+        return 4
+    } else if tagType == TypeDouble {
+        return 8
     } else {
         log.Panicf("can not determine tag-value size for type (%d): [%s]", tagType, TypeNames[tagType])
 
@@ -105,8 +127,12 @@ func (tagType TagTypePrimitive) IsValid() bool {
         tagType == TypeShort ||
         tagType == TypeLong ||
         tagType == TypeRational ||
+        tagType == TypeSignedByte ||
+        tagType == TypeSignedShort ||
         tagType == TypeSignedLong ||
         tagType == TypeSignedRational ||
+        tagType == TypeFloat ||
+        tagType == TypeDouble ||
         tagType == TypeUndefined
 }
 
@@ -118,9 +144,13 @@ var (
         TypeShort:          "SHORT",
         TypeLong:           "LONG",
         TypeRational:       "RATIONAL",
+        TypeSignedByte:     "SBYTE",
         TypeUndefined:      "UNDEFINED",
+        TypeSignedShort:    "SSHORT",
         TypeSignedLong:     "SLONG",
         TypeSignedRational: "SRATIONAL",
+        TypeFloat:          "FLOAT",
+        TypeDouble:         "DOUBLE",
 
         TypeAsciiNoNul: "_ASCII_NO_NUL",
     }
@@ -222,6 +252,66 @@ func FormatFromType(value interface{}, justFirst bool) (phrase string, err error
             return fmt.Sprintf("%v%s", t[0], valueSuffix), nil
         }
 
+        return fmt.Sprintf("%v", t), nil
+    case []int8:
+        if len(t) == 0 {
+            return "", nil
+        }
+
+        if justFirst == true {
+            var valueSuffix string
+            if len(t) > 1 {
+                valueSuffix = "..."
+            }
+
+            return fmt.Sprintf("%v%s", t[0], valueSuffix), nil
+        }
+
+        return fmt.Sprintf("%v", t), nil
+    case []int16:
+        if len(t) == 0 {
+            return "", nil
+        }
+
+        if justFirst == true {
+            var valueSuffix string
+            if len(t) > 1 {
+                valueSuffix = "..."
+            }
+
+            return fmt.Sprintf("%v%s", t[0], valueSuffix), nil
+        }
+
+        return fmt.Sprintf("%v", t), nil
+    case []float32:
+        if len(t) == 0 {
+            return "", nil
+        }
+
+        if justFirst == true {
+            var valueSuffix string
+            if len(t) > 1 {
+                valueSuffix = "..."
+            }
+
+            return fmt.Sprintf("%v%s", t[0], valueSuffix), nil
+        }
+
+        return fmt.Sprintf("%v", t), nil
+    case []float64:
+        if len(t) == 0 {
+            return "", nil
+        }
+
+        if justFirst == true {
+            var valueSuffix string
+            if len(t) > 1 {
+                valueSuffix = "..."
+            }
+
+            return fmt.Sprintf("%v%s", t[0], valueSuffix), nil
+        }
+
         return fmt.Sprintf("%v", t), nil
     case []SignedRational:
         if len(t) == 0 {
@@ -314,6 +404,16 @@ func Format(rawBytes []byte, tagType TagTypePrimitive, justFirst bool, byteOrder
 
         value, err = parser.ParseRationals(rawBytes, unitCount, byteOrder)
         log.PanicIf(err)
+    case TypeSignedByte:
+        var err error
+
+        value, err = parser.ParseSignedBytes(rawBytes, unitCount)
+        log.PanicIf(err)
+    case TypeSignedShort:
+        var err error
+
+        value, err = parser.ParseSignedShorts(rawBytes, unitCount, byteOrder)
+        log.PanicIf(err)
     case TypeSignedLong:
         var err error
 
@@ -324,6 +424,16 @@ func Format(rawBytes []byte, tagType TagTypePrimitive, justFirst bool, byteOrder
 
         value, err = parser.ParseSignedRationals(rawBytes, unitCount, byteOrder)
         log.PanicIf(err)
+    case TypeFloat:
+        var err error
+
+        value, err = parser.ParseFloats(rawBytes, unitCount, byteOrder)
+        log.PanicIf(err)
+    case TypeDouble:
+        var err error
+
+        value, err = parser.ParseDoubles(rawBytes, unitCount, byteOrder)
+        log.PanicIf(err)
     default:
         // Affects only "unknown" values, in general.
         log.Panicf("value of type [%s] can not be formatted into string", tagType.String())
@@ -388,11 +498,31 @@ func TranslateStringToType(tagType TagTypePrimitive, valueString string) (value
             Numerator:   uint32(numerator),
             Denominator: uint32(denominator),
         }, nil
+    } else if tagType == TypeSignedByte {
+        n, err := strconv.ParseInt(valueString, 10, 8)
+        log.PanicIf(err)
+
+        return int8(n), nil
+    } else if tagType == TypeSignedShort {
+        n, err := strconv.ParseInt(valueString, 10, 16)
+        log.PanicIf(err)
+
+        return int16(n), nil
     } else if tagType == TypeSignedLong {
         n, err := strconv.ParseInt(valueString, 10, 32)
         log.PanicIf(err)
 
         return int32(n), nil
+    } else if tagType == TypeFloat {
+        n, err := strconv.ParseFloat(valueString, 32)
+        log.PanicIf(err)
+
+        return float32(n), nil
+    } else if tagType == TypeDouble {
+        n, err := strconv.ParseFloat(valueString, 64)
+        log.PanicIf(err)
+
+        return n, nil
     } else if tagType == TypeSignedRational {
         parts := strings.SplitN(valueString, "/", 2)
 
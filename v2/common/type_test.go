@@ -0,0 +1,115 @@
+package exifcommon
+
+import (
+    "encoding/binary"
+    "math"
+    "testing"
+)
+
+func TestTagTypePrimitive_Size_newTypes(t *testing.T) {
+    testCases := []struct {
+        tagType  TagTypePrimitive
+        expected int
+    }{
+        {TypeSignedByte, 1},
+        {TypeSignedShort, 2},
+        {TypeFloat, 4},
+        {TypeDouble, 8},
+    }
+
+    for _, tc := range testCases {
+        if tc.tagType.Size() != tc.expected {
+            t.Fatalf("%s: expected size (%d), got (%d)", tc.tagType.String(), tc.expected, tc.tagType.Size())
+        }
+    }
+}
+
+func TestTagTypePrimitive_IsValid_newTypes(t *testing.T) {
+    for _, tagType := range []TagTypePrimitive{TypeSignedByte, TypeSignedShort, TypeFloat, TypeDouble} {
+        if tagType.IsValid() != true {
+            t.Fatalf("expected (%s) to be valid", tagType.String())
+        }
+    }
+}
+
+func TestFormat_signedByte(t *testing.T) {
+    raw := []byte{0xff, 0x02}
+
+    phrase, err := Format(raw, TypeSignedByte, false, binary.LittleEndian)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if phrase != "[-1 2]" {
+        t.Fatalf("unexpected phrase: [%s]", phrase)
+    }
+}
+
+func TestFormat_signedShort(t *testing.T) {
+    raw := make([]byte, 4)
+
+    var negativeOne int16 = -1
+    binary.LittleEndian.PutUint16(raw[0:2], uint16(negativeOne))
+    binary.LittleEndian.PutUint16(raw[2:4], 2)
+
+    phrase, err := Format(raw, TypeSignedShort, false, binary.LittleEndian)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if phrase != "[-1 2]" {
+        t.Fatalf("unexpected phrase: [%s]", phrase)
+    }
+}
+
+func TestFormat_float(t *testing.T) {
+    raw := make([]byte, 4)
+    binary.LittleEndian.PutUint32(raw, math.Float32bits(1.5))
+
+    phrase, err := Format(raw, TypeFloat, false, binary.LittleEndian)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if phrase != "[1.5]" {
+        t.Fatalf("unexpected phrase: [%s]", phrase)
+    }
+}
+
+func TestFormat_double(t *testing.T) {
+    raw := make([]byte, 8)
+    binary.LittleEndian.PutUint64(raw, math.Float64bits(1.5))
+
+    phrase, err := Format(raw, TypeDouble, false, binary.LittleEndian)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if phrase != "[1.5]" {
+        t.Fatalf("unexpected phrase: [%s]", phrase)
+    }
+}
+
+func TestTranslateStringToType_newTypes(t *testing.T) {
+    testCases := []struct {
+        tagType     TagTypePrimitive
+        valueString string
+        expected    interface{}
+    }{
+        {TypeSignedByte, "-5", int8(-5)},
+        {TypeSignedShort, "-500", int16(-500)},
+        {TypeFloat, "1.5", float32(1.5)},
+        {TypeDouble, "1.5", float64(1.5)},
+    }
+
+    for _, tc := range testCases {
+        value, err := TranslateStringToType(tc.tagType, tc.valueString)
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        if value != tc.expected {
+            t.Fatalf("%s: expected (%v), got (%v)", tc.tagType.String(), tc.expected, value)
+        }
+    }
+}